@@ -1,25 +1,51 @@
 package container
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"os"
+	"os/signal"
 	"reflect"
 	"sort"
+	"strings"
 	"sync"
+	"syscall"
+	"unsafe"
 )
 
 const ConstructorName = "New"
 const InitializerName = "Init"
+const StartName = "Start"
+const StopName = "Stop"
 
 type depContainer struct {
 	types []reflect.Type
-	impls map[reflect.Type]reflect.Type
+	impls map[reflect.Type][]reflect.Type
+	named map[string]reflect.Type
 	refs  map[reflect.Type]reflect.Value
-	creating map[reflect.Type]interface{}
+	creating map[reflect.Type]bool
+	resolving []resolveFrame
 	hasBuilt bool
+	buildOrder []reflect.Type
+	built map[reflect.Type]bool
+	providers []reflect.Value
+	providerFor map[reflect.Type]reflect.Value
+	selfTyp reflect.Type
 	wg sync.WaitGroup
 }
 
+// resolveFrame tracks a component currently being created, so that a
+// repeat visit can be reported as a cycle instead of an infinite
+// recursion. `via` describes the component's currently active outgoing
+// edge ("New" for its constructor, "field X" for a field) at the moment
+// a cycle is detected through it.
+type resolveFrame struct {
+	typ reflect.Type
+	via string
+}
+
 type Runnable interface {
 	Run()
 }
@@ -38,6 +64,25 @@ type Container interface {
 	// interface.
 	RegisterAsInterface(iface interface{}, comp interface{})
 
+	// RegisterNamed registers a component under a name, in addition to its
+	// type and any interfaces it implements. Use LoadNamed, or an
+	// `inject:"name=..."` struct tag, to resolve this specific binding
+	// when multiple components satisfy the same interface. A name is bound
+	// to the component's type, so two components of the same type
+	// registered under different names both resolve to the last one.
+	RegisterNamed(name string, comp interface{})
+
+	// Provide registers a factory function as a component. `fn`'s return
+	// value (optionally paired with a trailing error) becomes a component
+	// of its return type; `fn`'s parameters are resolved as dependencies
+	// the same way constructor arguments are. Useful for wiring up values
+	// that don't have a `New` method of their own, e.g. a *sql.DB.
+	Provide(fn interface{})
+
+	// Invoke behaves like Exec, but returns the function's first return
+	// value. Prefer the generic container.Invoke for typed call sites.
+	Invoke(fn interface{}) interface{}
+
 	// Load returns a pointer to the specified type or implementor of specified interface
 	// Panics if no type exists.
 	Load(iface interface{}) interface{}
@@ -46,10 +91,38 @@ type Container interface {
 	// and returns (nil, false) if none can be found.
 	TryLoad(iface interface{}) (interface{}, bool)
 
-	// Build iterates through registered components and attempts to
-	// resolve their requirements. Panics if any constructor arguments
-	// or public members cannot be satisfied with registered components.
-	Build() Container
+	// LoadNamed returns the component registered under name with
+	// RegisterNamed. Panics if no component was registered under that name.
+	LoadNamed(name string, iface interface{}) interface{}
+
+	// LoadAll returns every registered implementor of the given interface,
+	// in registration order.
+	LoadAll(iface interface{}) []interface{}
+
+	// Build iterates through registered components and providers and
+	// attempts to resolve their requirements, in dependency order.
+	// Returns a *CycleError if wiring would require a dependency cycle, or
+	// a *MissingDependencyError if a field, constructor parameter, or
+	// provider parameter can't be satisfied.
+	Build() (Container, error)
+
+	// MustBuild behaves like Build, but panics instead of returning an
+	// error. Useful at startup, where a wiring mistake should fail fast.
+	MustBuild() Container
+
+	// Start builds the container if it hasn't been built yet, then walks
+	// registered components in dependency-resolution order and calls
+	// `Start(ctx)` on any that implement it.
+	Start(ctx context.Context) error
+
+	// Stop walks registered components in the reverse of the order they
+	// were started and calls `Stop(ctx)` on any that implement it,
+	// aggregating any errors returned.
+	Stop(ctx context.Context) error
+
+	// RunForever calls Start, then blocks until SIGINT or SIGTERM is
+	// received, at which point it calls Stop and returns.
+	RunForever()
 
 	// Run will take the supplied Runnable, inject dependencies into it,
 	// and call .Run() on it.
@@ -84,7 +157,7 @@ func (c *depContainer) Register(comps ...interface{}) {
 func (c *depContainer) RegisterAsInterface(iface interface{}, comp interface{}) {
 	ifaceTyp := reflect.TypeOf(iface).Elem()
 	if ifaceTyp.Kind() != reflect.Interface {
-		log.Fatal(fmt.Sprintf("%+v is not an interface", iface))
+		panic(&NotAnInterfaceError{Type: ifaceTyp})
 	}
 
 	typ := reflect.TypeOf(comp)
@@ -94,11 +167,22 @@ func (c *depContainer) RegisterAsInterface(iface interface{}, comp interface{})
 	}
 
 	if !reflect.New(typ).Type().Implements(ifaceTyp) {
-		log.Fatal(fmt.Sprintf("%+v does not implement %+v", typ.Name(), ifaceTyp.Name()))
+		panic(&NotImplementedError{Type: typ, Iface: ifaceTyp})
+	}
+
+	c.types = append(c.types, typ)
+	c.impls[ifaceTyp] = append(c.impls[ifaceTyp], typ)
+}
+
+func (c *depContainer) RegisterNamed(name string, comp interface{}) {
+	typ := reflect.TypeOf(comp)
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+		c.refs[typ] = reflect.ValueOf(comp)
 	}
 
 	c.types = append(c.types, typ)
-	c.impls[ifaceTyp] = typ
+	c.named[name] = typ
 }
 
 func constructorArgCount(typ reflect.Type) int {
@@ -109,18 +193,85 @@ func constructorArgCount(typ reflect.Type) int {
 	return method.Type.NumIn()
 }
 
-func (c *depContainer) Build() Container {
-	sort.Slice(c.types, func(i, j int) bool {
-		return constructorArgCount(c.types[i]) < constructorArgCount(c.types[j])
-	})
+// buildItem is one thing Build must resolve: a registered component or a
+// Provide'd factory function. Sorting a mix of both by their argument
+// count approximates a dependency order, the same heuristic the original
+// component-only sort used.
+type buildItem interface {
+	argCount() int
+	build(c *depContainer)
+}
+
+type componentItem struct{ typ reflect.Type }
+
+func (i componentItem) argCount() int {
+	return constructorArgCount(i.typ)
+}
+
+// build creates the component if it isn't already built, and records it
+// in buildOrder if it wasn't already recorded. A component pre-supplied
+// as a pointer to Register never goes through createComponent - which is
+// where buildOrder is normally appended, to capture the order
+// dependencies were actually wired in - so it's appended here instead.
+// recordBuilt guards against double-appending a component that was
+// already pulled in and built as someone else's dependency before its
+// own turn in this loop came up.
+func (i componentItem) build(c *depContainer) {
+	if _, ok := c.refs[i.typ]; ok {
+		c.recordBuilt(i.typ)
+		return
+	}
+	c.createComponent(i.typ)
+}
+
+type providerItem struct{ fn reflect.Value }
+
+func (i providerItem) argCount() int {
+	return i.fn.Type().NumIn()
+}
+
+func (i providerItem) build(c *depContainer) {
+	c.recordBuilt(c.invokeProvider(i.fn))
+}
+
+// Build resolves every registered component and provider. Any of this
+// package's wiring errors (*CycleError, *MissingDependencyError,
+// *ProviderError and the others isWiringError accepts) raised while
+// resolving a dependency is recovered here and returned as err instead
+// of crashing the process; any other panic (a programmer error, not a
+// wiring error) propagates.
+func (c *depContainer) Build() (cont Container, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			e, ok := r.(error)
+			if !ok || !isWiringError(e) {
+				panic(r)
+			}
+			err = e
+		}
+	}()
 
-	// create registered types, skip those with refs already
+	items := make([]buildItem, 0, len(c.types)+len(c.providers))
 	for _, typ := range c.types {
-		_, ok := c.refs[typ]
-		if ok {
+		// A Provide'd type is registered in c.types so other components
+		// can depend on it, but it's built by its providerItem below, not
+		// by reflect.New'ing it as a bare component here.
+		if _, isProvided := c.providerFor[typ]; isProvided {
 			continue
 		}
-		c.createComponent(typ)
+		items = append(items, componentItem{typ})
+	}
+	for _, fn := range c.providers {
+		items = append(items, providerItem{fn})
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].argCount() < items[j].argCount()
+	})
+
+	c.buildOrder = c.buildOrder[:0]
+	c.built = make(map[reflect.Type]bool, len(items))
+	for _, item := range items {
+		item.build(c)
 	}
 
 	// initialize refs with initializers
@@ -130,76 +281,362 @@ func (c *depContainer) Build() Container {
 		}
 	}
 	c.hasBuilt = true
-	return c
+	return c, nil
 }
 
-func (c *depContainer) verifyRegistry(typ reflect.Type) {
-	for _, rt := range c.types {
-		if rt == typ {
-			return
+// MustBuild behaves like Build, but panics with the error instead of
+// returning it.
+func (c *depContainer) MustBuild() Container {
+	cont, err := c.Build()
+	if err != nil {
+		panic(err)
+	}
+	return cont
+}
+
+// Start implies Build: it wires up the registry if that hasn't happened
+// yet, then starts every built component that has a `Start(ctx) error`
+// method, in the order components were resolved during Build.
+func (c *depContainer) Start(ctx context.Context) error {
+	if !c.hasBuilt {
+		if _, err := c.Build(); err != nil {
+			return err
+		}
+	}
+
+	for _, typ := range c.buildOrder {
+		if typ == c.selfTyp {
+			// The container registers itself as its own Container
+			// implementor so components can require one; it isn't a
+			// component whose own Start/Stop should be reflected into.
+			continue
+		}
+		val, ok := c.refs[typ]
+		if !ok {
+			continue
+		}
+		if err := callLifecycleMethod(val, StartName, ctx); err != nil {
+			return fmt.Errorf("starting %s: %w", typ.Name(), err)
+		}
+	}
+	return nil
+}
+
+// Stop tears components back down in the reverse of their start order,
+// calling `Stop(ctx) error` on any that implement it. Every component is
+// given a chance to shut down even if an earlier one errors; the
+// resulting errors are joined together.
+func (c *depContainer) Stop(ctx context.Context) error {
+	var errs []error
+	for i := len(c.buildOrder) - 1; i >= 0; i-- {
+		typ := c.buildOrder[i]
+		if typ == c.selfTyp {
+			continue
+		}
+		val, ok := c.refs[typ]
+		if !ok {
+			continue
+		}
+		if err := callLifecycleMethod(val, StopName, ctx); err != nil {
+			errs = append(errs, fmt.Errorf("stopping %s: %w", typ.Name(), err))
 		}
 	}
-	log.Fatal(fmt.Sprintf("no such dependency in registry: %+v %+v ", typ, typ.Kind()))
+	return errors.Join(errs...)
+}
+
+// RunForever starts the container and blocks until SIGINT or SIGTERM is
+// received, then stops the container before returning. It's the opt-in
+// entrypoint for apps whose components are long-running (servers, pools)
+// rather than one-shot.
+func (c *depContainer) RunForever() {
+	if err := c.Start(context.Background()); err != nil {
+		log.Fatal(err)
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	<-sigCh
+
+	if err := c.Stop(context.Background()); err != nil {
+		log.Printf("error stopping container: %v", err)
+	}
 }
 
-func (c *depContainer) findDep(bld, typ reflect.Type) reflect.Value {
-	fmt.Printf("Typ.Kind(): %+v\n", typ.Kind())
+// callLifecycleMethod invokes the named method on val with ctx as its
+// only argument, if val's type has such a method. It reports the error
+// returned by the method, if any.
+func callLifecycleMethod(val reflect.Value, name string, ctx context.Context) error {
+	if _, ok := val.Type().MethodByName(name); !ok {
+		return nil
+	}
+
+	out := val.MethodByName(name).Call([]reflect.Value{reflect.ValueOf(ctx)})
+	if len(out) == 0 {
+		return nil
+	}
+	err, _ := out[0].Interface().(error)
+	return err
+}
+
+// verifyRegistry panics with a *MissingDependencyError if typ was never
+// registered. bld and member identify the component and the field or
+// constructor that needed typ, for the error message; both may be zero
+// values when the lookup didn't originate from resolving a dependency.
+func (c *depContainer) verifyRegistry(bld reflect.Type, member string, typ reflect.Type) {
+	if containsType(c.types, typ) {
+		return
+	}
+	panic(&MissingDependencyError{Component: bld, Member: member, Missing: typ})
+}
+
+func (c *depContainer) findDep(bld reflect.Type, member string, typ reflect.Type) reflect.Value {
 	switch typ.Kind() {
 	case reflect.Ptr:
-		return c.getOrCreateComponent(typ.Elem())
+		return c.getOrCreateComponent(bld, member, typ.Elem())
 	case reflect.Interface:
-		return c.getOrCreateImpl(bld, typ)
+		return c.getOrCreateImpl(bld, member, typ)
+	case reflect.Slice:
+		return c.getOrCreateAllImpls(typ.Elem())
 	default:
-		return c.getOrCreateComponent(typ).Elem()
+		return c.getOrCreateComponent(bld, member, typ).Elem()
 	}
 }
 
-func (c *depContainer) getOrCreateComponent(typ reflect.Type) reflect.Value {
-	c.verifyRegistry(typ)
+// getOrCreateAllImpls resolves every registered implementor of elemTyp
+// (an interface or concrete type) and returns them as a []elemTyp, for
+// wiring up fields and constructor args declared as a slice.
+func (c *depContainer) getOrCreateAllImpls(elemTyp reflect.Type) reflect.Value {
+	var implTyps []reflect.Type
+	if elemTyp.Kind() == reflect.Interface {
+		implTyps = c.allImplementors(elemTyp)
+	} else if _, ok := c.refs[elemTyp]; ok || containsType(c.types, elemTyp) {
+		implTyps = []reflect.Type{elemTyp}
+	}
+
+	slice := reflect.MakeSlice(reflect.SliceOf(elemTyp), 0, len(implTyps))
+	for _, typ := range implTyps {
+		comp := c.getOrCreateComponent(nil, "", typ)
+		if elemTyp.Kind() == reflect.Interface {
+			slice = reflect.Append(slice, comp)
+		} else {
+			slice = reflect.Append(slice, comp.Elem())
+		}
+	}
+	return slice
+}
+
+func containsType(types []reflect.Type, typ reflect.Type) bool {
+	for _, t := range types {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// getOrCreateComponent resolves typ to a built instance, creating it if
+// necessary. If typ is a Provide'd type that hasn't run yet - because its
+// build-order turn hasn't come up, but something else already needs its
+// value - its provider is invoked on demand rather than falling through
+// to createComponent, which would otherwise just zero-value it via
+// reflect.New.
+func (c *depContainer) getOrCreateComponent(bld reflect.Type, member string, typ reflect.Type) reflect.Value {
+	c.verifyRegistry(bld, member, typ)
 	if comp, ok := c.refs[typ]; ok {
 		return comp
 	}
+	if fn, ok := c.providerFor[typ]; ok {
+		c.invokeProvider(fn)
+		return c.refs[typ]
+	}
 	return c.createComponent(typ)
 }
 
+// injectTag is the parsed form of an `inject:"..."` struct tag:
+//   - `inject:"-"` skips the field entirely
+//   - `inject:"optional"` leaves the field at its zero value instead of
+//     panicking when no dependency can satisfy it
+//   - `inject:"name=foo"` binds the field to whatever was registered with
+//     RegisterNamed("foo", ...), taking precedence over the usual
+//     interface-implementor or concrete-type lookup
+//
+// Options are comma-separated, e.g. `inject:"name=foo,optional"`.
+type injectTag struct {
+	skip     bool
+	optional bool
+	name     string
+}
+
+func parseInjectTag(raw string) injectTag {
+	if raw == "-" {
+		return injectTag{skip: true}
+	}
+	var tag injectTag
+	for _, part := range strings.Split(raw, ",") {
+		switch {
+		case part == "optional":
+			tag.optional = true
+		default:
+			if name, ok := strings.CutPrefix(part, "name="); ok {
+				tag.name = name
+			}
+		}
+	}
+	return tag
+}
+
+// resolveField resolves the dependency for a struct field per tag: an
+// explicit `name=` binding wins over the normal interface/concrete-type
+// lookup that findDep performs. If tag.optional is set, any resolution
+// failure - a missing dependency or a cycle - is reported as (zero
+// Value, false) instead of panicking.
+func (c *depContainer) resolveField(bld reflect.Type, member string, tag injectTag, fldTyp reflect.Type) (dep reflect.Value, ok bool) {
+	if tag.optional {
+		defer func() {
+			if r := recover(); r != nil {
+				if _, isErr := r.(error); isErr {
+					ok = false
+					return
+				}
+				panic(r)
+			}
+		}()
+	}
+
+	if tag.name != "" {
+		return c.getOrCreateNamed(tag.name), true
+	}
+	return c.findDep(bld, member, fldTyp), true
+}
+
 func (c *depContainer) wireComponent(typ reflect.Type, val reflect.Value) reflect.Value {
 	_, ok := val.Type().MethodByName(ConstructorName)
 	// No constructor exists, set public members instead
 	if !ok {
+		structTyp := val.Elem().Type()
 		for i := 0; i < val.Elem().NumField(); i++ {
+			sf := structTyp.Field(i)
+			rawTag, hasTag := sf.Tag.Lookup("inject")
+			tag := parseInjectTag(rawTag)
+			if tag.skip {
+				continue
+			}
+
 			fld := val.Elem().Field(i)
 			if !fld.CanSet() {
+				if !hasTag {
+					continue
+				}
+				// Unexported but tagged: make it settable so callers
+				// aren't forced to expose internal dependencies.
+				fld = reflect.NewAt(fld.Type(), unsafe.Pointer(fld.UnsafeAddr())).Elem()
+			}
+
+			member := "field " + sf.Name
+			c.setVia(typ, member)
+			dep, found := c.resolveField(typ, member, tag, fld.Type())
+			if !found {
 				continue
 			}
-			dep := c.findDep(typ, fld.Type())
 			fld.Set(dep)
 		}
 		c.refs[typ] = val
 		return val
 	}
 
-	// Gather required depContainer and call constructor
+	// Gather required dependencies and call constructor
 	method := val.MethodByName(ConstructorName)
-	c.executeFunc(typ, method)
+	c.setVia(typ, ConstructorName)
+	c.executeFunc(typ, ConstructorName, method)
 	c.refs[typ] = val
 	return val
 }
 
+// createComponent instantiates typ and wires up its dependencies. It
+// pushes typ onto c.resolving for the duration of the call, so that a
+// dependency which requires typ again - directly or transitively - is
+// reported as a *CycleError instead of recursing forever. It records typ
+// in buildOrder only once wiring actually succeeds, so Start/Stop see
+// components in the order they were really constructed - which, for a
+// component pulled in as someone else's dependency, can be well before
+// its own turn in the registration-order loop.
 func (c *depContainer) createComponent(typ reflect.Type) reflect.Value {
-	// Keep track of which records we're currently inputs the middle of creating
-	// This prevents trying to use a type to satisfy it's own interface requirement
-	// and also prevents cyclic references.
-	c.creating[typ] = nil
-	defer delete(c.creating, typ)
+	if c.creating[typ] {
+		panic(&CycleError{Path: c.cyclePath(typ)})
+	}
+
+	c.creating[typ] = true
+	c.resolving = append(c.resolving, resolveFrame{typ: typ})
+	defer func() {
+		c.resolving = c.resolving[:len(c.resolving)-1]
+		delete(c.creating, typ)
+	}()
 
 	val := reflect.New(typ)
-	return c.wireComponent(typ, val)
+	wired := c.wireComponent(typ, val)
+	c.recordBuilt(typ)
+	return wired
+}
+
+// recordBuilt appends typ to buildOrder the first time it's built,
+// whether that happens via its own turn in Build's loop or earlier, as a
+// dependency pulled in while constructing something else. Later calls
+// for the same typ are no-ops, so Start/Stop don't run a component's
+// lifecycle methods more than once.
+func (c *depContainer) recordBuilt(typ reflect.Type) {
+	if c.built[typ] {
+		return
+	}
+	c.built[typ] = true
+	c.buildOrder = append(c.buildOrder, typ)
+}
+
+// setVia records the dependency typ is currently resolving, so that a
+// cycle detected further down the call stack can be reported with
+// context (e.g. "field Store" or "New") instead of just a bare type name.
+func (c *depContainer) setVia(typ reflect.Type, via string) {
+	for i := len(c.resolving) - 1; i >= 0; i-- {
+		if c.resolving[i].typ == typ {
+			c.resolving[i].via = via
+			return
+		}
+	}
+}
+
+// cyclePath renders the chain of components currently being created that
+// closes into a cycle through typ, e.g.
+// []string{"A.New", "B (field Store)", "A"}.
+func (c *depContainer) cyclePath(typ reflect.Type) []string {
+	start := 0
+	for i, frame := range c.resolving {
+		if frame.typ == typ {
+			start = i
+			break
+		}
+	}
+
+	path := make([]string, 0, len(c.resolving)-start+1)
+	for _, frame := range c.resolving[start:] {
+		path = append(path, frame.describe())
+	}
+	return append(path, typ.Name())
 }
 
+func (f resolveFrame) describe() string {
+	switch f.via {
+	case "":
+		return f.typ.Name()
+	case ConstructorName:
+		return fmt.Sprintf("%s.%s", f.typ.Name(), f.via)
+	default:
+		return fmt.Sprintf("%s (%s)", f.typ.Name(), f.via)
+	}
+}
 
 func (c *depContainer) Load(iface interface{}) interface{} {
-	ref, ok := c.TryLoad(iface); if !ok {
-		log.Fatal(fmt.Sprintf("no instance of type found %+v", reflect.TypeOf(iface)))
+	ref, ok := c.TryLoad(iface)
+	if !ok {
+		panic(&NotFoundError{Type: reflect.TypeOf(iface)})
 	}
 	return ref
 }
@@ -216,64 +653,208 @@ func (c *depContainer) TryLoad(iface interface{}) (interface{}, bool) {
 	return ref.Interface(), true
 }
 
+func (c *depContainer) LoadNamed(name string, iface interface{}) interface{} {
+	typ, ok := c.named[name]
+	if !ok {
+		panic(&NamedNotFoundError{Name: name})
+	}
+	ref, ok := c.refs[typ]
+	if !ok {
+		panic(&NotBuiltError{Name: name})
+	}
+	return ref.Interface()
+}
+
+// LoadAll returns every built implementor of the given interface, in the
+// order they were registered.
+func (c *depContainer) LoadAll(iface interface{}) []interface{} {
+	ifaceTyp := reflect.TypeOf(iface).Elem()
+
+	var result []interface{}
+	for _, typ := range c.allImplementors(ifaceTyp) {
+		if ref, ok := c.refs[typ]; ok {
+			result = append(result, ref.Interface())
+		}
+	}
+	return result
+}
+
 func (c *depContainer) getNormalizedType(typ reflect.Type) (reflect.Type, bool) {
 	switch typ.Kind() {
 	case reflect.Ptr:
 		return c.getNormalizedType(typ.Elem())
 	case reflect.Interface:
-		typ, ok := c.impls[typ]
-		return typ, ok
+		impls, ok := c.impls[typ]
+		if !ok || len(impls) == 0 {
+			return nil, false
+		}
+		return impls[0], true
 	}
 	return typ, true
 }
 
-func (c *depContainer) findImplementor(bld, iface reflect.Type) reflect.Type {
-	typ, ok := c.impls[iface]
-	if ok {
-		if _, creating := c.creating[typ]; !creating {
+func (c *depContainer) findImplementor(bld reflect.Type, member string, iface reflect.Type) reflect.Type {
+	for _, typ := range c.impls[iface] {
+		if !c.creating[typ] {
 			return typ
 		}
 	}
 
-	for _, typ = range c.types {
+	for _, typ := range c.types {
 		ptrTyp := reflect.New(typ).Type()
 		if ptrTyp.Implements(iface) {
-			if _, creating := c.creating[typ]; creating {
+			if c.creating[typ] {
 				continue
 			}
-			c.impls[iface] = typ
+			if !containsType(c.impls[iface], typ) {
+				c.impls[iface] = append(c.impls[iface], typ)
+			}
 			return typ
 		}
 	}
-	panic(fmt.Sprintf("%+v implementor not found, required by %+v", iface.Name(), bld.Name()))
+	panic(&MissingDependencyError{Component: bld, Member: member, Missing: iface})
+}
+
+// allImplementors returns every type registered that implements iface,
+// the same way findImplementor scans for one: types explicitly bound
+// via RegisterAsInterface (c.impls[iface]) plus any plain Register'd
+// type that happens to implement it. A type found only by the scan is
+// cached into c.impls[iface], the same lazy-registration findImplementor
+// does, so later lookups (single or all) don't need to scan again.
+func (c *depContainer) allImplementors(iface reflect.Type) []reflect.Type {
+	var result []reflect.Type
+	for _, typ := range c.types {
+		if containsType(result, typ) {
+			continue
+		}
+		if reflect.New(typ).Type().Implements(iface) {
+			if !containsType(c.impls[iface], typ) {
+				c.impls[iface] = append(c.impls[iface], typ)
+			}
+			result = append(result, typ)
+		}
+	}
+	return result
 }
 
-func (c *depContainer) getOrCreateImpl(bld, iface reflect.Type) reflect.Value {
-	implTyp := c.findImplementor(bld, iface)
-	return c.getOrCreateComponent(implTyp)
+func (c *depContainer) getOrCreateImpl(bld reflect.Type, member string, iface reflect.Type) reflect.Value {
+	implTyp := c.findImplementor(bld, member, iface)
+	return c.getOrCreateComponent(bld, member, implTyp)
 }
 
-func (c *depContainer) executeFunc(typ reflect.Type, fn reflect.Value) {
+func (c *depContainer) getOrCreateNamed(name string) reflect.Value {
+	typ, ok := c.named[name]
+	if !ok {
+		panic(&NamedNotFoundError{Name: name})
+	}
+	return c.getOrCreateComponent(nil, "", typ)
+}
+
+// executeFunc resolves fn's parameters as dependencies and calls it. bld
+// and via identify the component and member (e.g. "New") that fn is
+// being called on behalf of, for missing-dependency and cycle reporting;
+// both are zero values for a one-off call like Exec or Invoke that isn't
+// tied to a particular component.
+func (c *depContainer) executeFunc(bld reflect.Type, via string, fn reflect.Value) []reflect.Value {
 	argCount := fn.Type().NumIn()
 	inputs := make([]reflect.Value, argCount)
 	for i := 0; i < argCount; i++ {
 		in := fn.Type().In(i)
-		inputs[i] = c.findDep(typ, in)
+		inputs[i] = c.findDep(bld, via, in)
 	}
-	fn.Call(inputs)
+	return fn.Call(inputs)
 }
 
 func (c *depContainer) Exec(e interface{}) {
 	if !c.hasBuilt {
-		c.Build()
+		c.MustBuild()
 	}
 	val := reflect.ValueOf(e)
-	c.executeFunc(val.Type(), val)
+	c.executeFunc(nil, "", val)
+}
+
+func (c *depContainer) Invoke(fn interface{}) interface{} {
+	if !c.hasBuilt {
+		c.MustBuild()
+	}
+	val := reflect.ValueOf(fn)
+	out := c.executeFunc(nil, "", val)
+	if len(out) == 0 {
+		return nil
+	}
+	return out[0].Interface()
+}
+
+// Provide registers fn as a factory for its return type, recording that
+// type in the registry immediately (rather than waiting for fn to run)
+// so that any other component's constructor or field can depend on it
+// regardless of build order. fn must be a function returning a value,
+// optionally paired with a trailing error.
+func (c *depContainer) Provide(fn interface{}) {
+	val := reflect.ValueOf(fn)
+	fnTyp := val.Type()
+	if fnTyp.Kind() != reflect.Func || fnTyp.NumOut() == 0 {
+		panic("container.Provide: fn must be a function returning a value")
+	}
+
+	outTyp := fnTyp.Out(0)
+	if outTyp.Kind() == reflect.Ptr {
+		outTyp = outTyp.Elem()
+	}
+	c.types = append(c.types, outTyp)
+	c.providers = append(c.providers, val)
+	c.providerFor[outTyp] = val
+}
+
+// invokeProvider calls a function registered with Provide, resolving its
+// parameters as dependencies, and stores its return value in refs keyed
+// by the return type. It's idempotent - a second call for a provider
+// that has already run is a no-op - so both Build's own pass over
+// providerItems and an on-demand call from getOrCreateComponent can
+// invoke the same provider without it running twice. A provider that
+// needs its own return type again, directly or through other providers,
+// is reported as a *CycleError. An error return aborts the build.
+func (c *depContainer) invokeProvider(fn reflect.Value) reflect.Type {
+	fnTyp := fn.Type()
+	outTyp := fnTyp.Out(0)
+	if outTyp.Kind() == reflect.Ptr {
+		outTyp = outTyp.Elem()
+	}
+	if _, ok := c.refs[outTyp]; ok {
+		return outTyp
+	}
+	if c.creating[outTyp] {
+		panic(&CycleError{Path: c.cyclePath(outTyp)})
+	}
+
+	c.creating[outTyp] = true
+	c.resolving = append(c.resolving, resolveFrame{typ: outTyp})
+	defer func() {
+		c.resolving = c.resolving[:len(c.resolving)-1]
+		delete(c.creating, outTyp)
+	}()
+
+	out := c.executeFunc(nil, "", fn)
+	if len(out) > 1 {
+		if err, ok := out[1].Interface().(error); ok && err != nil {
+			panic(&ProviderError{Func: fnTyp, Err: err})
+		}
+	}
+
+	result := out[0]
+	if result.Type().Kind() != reflect.Ptr {
+		ptr := reflect.New(result.Type())
+		ptr.Elem().Set(result)
+		result = ptr
+	}
+	c.refs[outTyp] = result
+	c.recordBuilt(outTyp)
+	return outTyp
 }
 
 func (c *depContainer) Run(r Runnable) {
 	if !c.hasBuilt {
-		c.Build()
+		c.MustBuild()
 	}
 
 	val := reflect.ValueOf(r)
@@ -303,11 +884,14 @@ func (c *depContainer) Wait() {
 
 func New() Container {
 	c := &depContainer{
-		impls: make(map[reflect.Type]reflect.Type),
+		impls: make(map[reflect.Type][]reflect.Type),
+		named: make(map[string]reflect.Type),
 		refs:  make(map[reflect.Type]reflect.Value),
-		creating:  make(map[reflect.Type]interface{}),
+		creating:  make(map[reflect.Type]bool),
+		providerFor: make(map[reflect.Type]reflect.Value),
 		hasBuilt: false,
 	}
+	c.selfTyp = reflect.TypeOf(c).Elem()
 	c.RegisterAsInterface((*Container)(nil), c)
 	return c
 }
@@ -0,0 +1,224 @@
+package container
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestStartStopNoSelfRecursion guards against the container reflecting
+// Start/Stop back onto itself: New() registers the container as its own
+// Container implementor, so without a guard callLifecycleMethod finds
+// *depContainer's own Start/Stop methods in buildOrder and calls them
+// forever.
+func TestStartStopNoSelfRecursion(t *testing.T) {
+	done := make(chan error, 1)
+	go func() {
+		c := New()
+		if _, err := c.Build(); err != nil {
+			done <- err
+			return
+		}
+		if err := c.Start(context.Background()); err != nil {
+			done <- err
+			return
+		}
+		done <- c.Stop(context.Background())
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Start/Stop did not return; container is recursing into itself")
+	}
+}
+
+type orderRecorder struct {
+	events []string
+}
+
+type loggerComp struct {
+	Rec *orderRecorder `inject:""`
+}
+
+func (l *loggerComp) Start(ctx context.Context) error {
+	l.Rec.events = append(l.Rec.events, "start:logger")
+	return nil
+}
+
+func (l *loggerComp) Stop(ctx context.Context) error {
+	l.Rec.events = append(l.Rec.events, "stop:logger")
+	return nil
+}
+
+type cacheComp struct {
+	Logger *loggerComp    `inject:""`
+	Rec    *orderRecorder `inject:""`
+}
+
+func (c *cacheComp) Start(ctx context.Context) error {
+	c.Rec.events = append(c.Rec.events, "start:cache")
+	return nil
+}
+
+func (c *cacheComp) Stop(ctx context.Context) error {
+	c.Rec.events = append(c.Rec.events, "stop:cache")
+	return nil
+}
+
+func indexOfName(types []reflect.Type, name string) int {
+	for i, typ := range types {
+		if typ.Name() == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// TestBuildOrderReflectsConstruction registers cacheComp (whose field
+// pulls in loggerComp) before loggerComp itself. buildOrder must reflect
+// the order components were actually wired, not registration order, so
+// loggerComp - wired while constructing cacheComp's field - is started
+// first and stopped last.
+func TestBuildOrderReflectsConstruction(t *testing.T) {
+	rec := &orderRecorder{}
+	c := New()
+	c.Register(rec)
+	c.Register(cacheComp{}, loggerComp{})
+	if _, err := c.Build(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	dc := c.(*depContainer)
+	loggerIdx := indexOfName(dc.buildOrder, "loggerComp")
+	cacheIdx := indexOfName(dc.buildOrder, "cacheComp")
+	if loggerIdx == -1 || cacheIdx == -1 {
+		t.Fatalf("expected both components in buildOrder, got %v", dc.buildOrder)
+	}
+	if loggerIdx > cacheIdx {
+		t.Fatalf("loggerComp was constructed while wiring cacheComp's field, so it must precede cacheComp in buildOrder: %v", dc.buildOrder)
+	}
+
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.Stop(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"start:logger", "start:cache", "stop:cache", "stop:logger"}
+	if len(rec.events) != len(want) {
+		t.Fatalf("got events %v, want %v", rec.events, want)
+	}
+	for i := range want {
+		if rec.events[i] != want[i] {
+			t.Fatalf("got events %v, want %v", rec.events, want)
+		}
+	}
+}
+
+type dbConn struct {
+	dsn string
+}
+
+type repoComp struct {
+	DB *dbConn `inject:""`
+}
+
+// TestProvideOutputResolvableByDependent guards against a Provide'd
+// type never being usable as a dependency: repoComp is registered
+// before the provider that supplies its *dbConn field is, so resolving
+// it requires the container to know *dbConn is coming, not just that
+// it's already been built.
+func TestProvideOutputResolvableByDependent(t *testing.T) {
+	c := New()
+	c.Register(repoComp{})
+	c.Provide(func() *dbConn { return &dbConn{dsn: "postgres://"} })
+	if _, err := c.Build(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	repo := c.Load(&repoComp{}).(*repoComp)
+	if repo.DB == nil || repo.DB.dsn != "postgres://" {
+		t.Fatalf("repoComp.DB not wired from provider: %+v", repo.DB)
+	}
+}
+
+// TestProvideRejectsInvalidFunctionShape guards against invokeProvider
+// panicking with a raw runtime.boundsError (indexing out[0] of a
+// no-return-value function) that Build's recover would otherwise
+// swallow into an opaque generic error. Provide validates fn's shape
+// immediately, so the mistake is obvious at the call site.
+func TestProvideRejectsInvalidFunctionShape(t *testing.T) {
+	c := New()
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatal("expected Provide to panic for a function with no return value")
+		}
+	}()
+	c.Provide(func() {})
+}
+
+// TestBuildOnlyRecoversWiringErrors guards against Build's recover
+// swallowing a programmer error (here, a provider that panics with a
+// plain out-of-range index) into a generic error indistinguishable from
+// a documented *CycleError/*MissingDependencyError/etc.
+func TestBuildOnlyRecoversWiringErrors(t *testing.T) {
+	c := New()
+	c.Provide(func() *int {
+		s := []int{}
+		return &s[5]
+	})
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected Build to propagate the non-wiring panic instead of swallowing it")
+		}
+	}()
+	c.Build()
+	t.Fatal("expected Build to panic")
+}
+
+type nodeA struct {
+	B *nodeB `inject:""`
+}
+
+type nodeB struct {
+	A *nodeA `inject:"optional"`
+}
+
+// TestOptionalTagAbsorbsCycle exercises the cycle detector and the
+// optional struct tag together: nodeA requires nodeB, and nodeB has an
+// optional back-reference to nodeA that would otherwise be a cycle.
+// Since the field is optional, the CycleError that createComponent
+// raises while resolving it must be absorbed rather than propagated,
+// and the cycle-tracking state (creating/resolving) must come out clean
+// so the rest of the build isn't left in a broken state.
+func TestOptionalTagAbsorbsCycle(t *testing.T) {
+	c := New()
+	c.Register(nodeA{}, nodeB{})
+	if _, err := c.Build(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	a := c.Load(&nodeA{}).(*nodeA)
+	if a.B == nil {
+		t.Fatal("nodeA.B should have resolved to nodeB")
+	}
+	if a.B.A != nil {
+		t.Fatalf("nodeB.A should have been left nil, the cycle back to nodeA being optional: got %v", a.B.A)
+	}
+
+	dc := c.(*depContainer)
+	if len(dc.creating) != 0 {
+		t.Fatalf("creating map should be empty after Build, got %v", dc.creating)
+	}
+	if len(dc.resolving) != 0 {
+		t.Fatalf("resolving stack should be empty after Build, got %v", dc.resolving)
+	}
+}
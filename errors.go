@@ -0,0 +1,115 @@
+package container
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// MissingDependencyError reports that a field, constructor parameter, or
+// provider parameter required a type that no registered component or
+// provider can satisfy.
+type MissingDependencyError struct {
+	Component reflect.Type // the component or provider that needed it, if known
+	Member    string       // the field or constructor describing the requirement, if known
+	Missing   reflect.Type // the type that couldn't be resolved
+}
+
+func (e *MissingDependencyError) Error() string {
+	if e.Component == nil {
+		return fmt.Sprintf("no dependency registered for %s", e.Missing)
+	}
+	return fmt.Sprintf("%s.%s requires %s, which is not registered", e.Component.Name(), e.Member, e.Missing)
+}
+
+// CycleError reports a dependency cycle discovered while resolving
+// components, e.g. "A.New -> B (field Store) -> A".
+type CycleError struct {
+	Path []string
+}
+
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("dependency cycle: %s", strings.Join(e.Path, " -> "))
+}
+
+// NotAnInterfaceError reports that RegisterAsInterface was called with a
+// type that is not an interface.
+type NotAnInterfaceError struct {
+	Type reflect.Type
+}
+
+func (e *NotAnInterfaceError) Error() string {
+	return fmt.Sprintf("%s is not an interface", e.Type)
+}
+
+// NotImplementedError reports that a component passed to
+// RegisterAsInterface doesn't implement the target interface.
+type NotImplementedError struct {
+	Type  reflect.Type
+	Iface reflect.Type
+}
+
+func (e *NotImplementedError) Error() string {
+	return fmt.Sprintf("%s does not implement %s", e.Type, e.Iface)
+}
+
+// NotFoundError reports that Load couldn't find a registered instance of
+// the requested type.
+type NotFoundError struct {
+	Type reflect.Type
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("no instance of type found %s", e.Type)
+}
+
+// NamedNotFoundError reports that LoadNamed, or an `inject:"name=..."`
+// struct tag, referenced a name that was never registered with
+// RegisterNamed.
+type NamedNotFoundError struct {
+	Name string
+}
+
+func (e *NamedNotFoundError) Error() string {
+	return fmt.Sprintf("no component registered under name %q", e.Name)
+}
+
+// NotBuiltError reports that a named component was registered but hasn't
+// been created yet, because Build hasn't run.
+type NotBuiltError struct {
+	Name string
+}
+
+func (e *NotBuiltError) Error() string {
+	return fmt.Sprintf("component %q has not been built yet", e.Name)
+}
+
+// ProviderError wraps the error returned by a Provide'd factory function.
+type ProviderError struct {
+	Func reflect.Type
+	Err  error
+}
+
+func (e *ProviderError) Error() string {
+	return fmt.Sprintf("provider %s failed: %v", e.Func, e.Err)
+}
+
+func (e *ProviderError) Unwrap() error {
+	return e.Err
+}
+
+// isWiringError reports whether err is one of this package's own wiring
+// errors, as opposed to an unrelated panic (a programmer error, such as a
+// slice index out of range inside a misbehaving provider) that happens to
+// implement error. Build only recovers the former; the latter propagates
+// so it isn't mistaken for a documented *CycleError/*MissingDependencyError.
+func isWiringError(err error) bool {
+	switch err.(type) {
+	case *CycleError, *MissingDependencyError, *NotAnInterfaceError,
+		*NotImplementedError, *NotFoundError, *NamedNotFoundError,
+		*NotBuiltError, *ProviderError:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,81 @@
+package container
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type cycA struct {
+	B *cycB `inject:""`
+}
+
+type cycB struct {
+	A *cycA `inject:""`
+}
+
+// TestBuildReportsCycleError checks that a genuine (non-optional)
+// dependency cycle comes back from Build as a *CycleError whose Path
+// names both components and whose Error() message says so, rather than
+// just asserting Build returned *some* error.
+func TestBuildReportsCycleError(t *testing.T) {
+	c := New()
+	c.Register(cycA{}, cycB{})
+
+	_, err := c.Build()
+	if err == nil {
+		t.Fatal("expected a cycle error, got nil")
+	}
+
+	cycErr, ok := err.(*CycleError)
+	if !ok {
+		t.Fatalf("got error %v (%T), want *CycleError", err, err)
+	}
+	if len(cycErr.Path) < 2 {
+		t.Fatalf("expected a multi-step cycle path, got %v", cycErr.Path)
+	}
+	joined := strings.Join(cycErr.Path, " -> ")
+	if !strings.Contains(joined, "cycA") || !strings.Contains(joined, "cycB") {
+		t.Fatalf("cycle path should mention both components, got %v", cycErr.Path)
+	}
+	if msg := cycErr.Error(); !strings.Contains(msg, "dependency cycle") {
+		t.Fatalf("got message %q, want it to mention a dependency cycle", msg)
+	}
+}
+
+type missingDep struct{}
+
+type needsMissing struct {
+	Dep *missingDep `inject:""`
+}
+
+// TestBuildReportsMissingDependencyError checks that a field with no
+// matching registration comes back from Build as a
+// *MissingDependencyError naming the requiring component, the field,
+// and the missing type.
+func TestBuildReportsMissingDependencyError(t *testing.T) {
+	c := New()
+	c.Register(needsMissing{})
+
+	_, err := c.Build()
+	if err == nil {
+		t.Fatal("expected a missing-dependency error, got nil")
+	}
+
+	missingErr, ok := err.(*MissingDependencyError)
+	if !ok {
+		t.Fatalf("got error %v (%T), want *MissingDependencyError", err, err)
+	}
+	if missingErr.Component != reflect.TypeOf(needsMissing{}) {
+		t.Fatalf("got Component %v, want %v", missingErr.Component, reflect.TypeOf(needsMissing{}))
+	}
+	if missingErr.Member != "field Dep" {
+		t.Fatalf("got Member %q, want %q", missingErr.Member, "field Dep")
+	}
+	if missingErr.Missing != reflect.TypeOf(missingDep{}) {
+		t.Fatalf("got Missing %v, want %v", missingErr.Missing, reflect.TypeOf(missingDep{}))
+	}
+	if msg := missingErr.Error(); !strings.Contains(msg, "needsMissing") || !strings.Contains(msg, "missingDep") {
+		t.Fatalf("got message %q, want it to name both types", msg)
+	}
+}
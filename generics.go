@@ -0,0 +1,73 @@
+package container
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// tryAssert converts v - always a pointer, since the container only ever
+// stores pointers - to T. If T is itself a pointer or interface type, v
+// is asserted directly; otherwise v is asserted as *T and dereferenced,
+// since T is registered by its concrete, non-pointer type.
+func tryAssert[T any](v interface{}) (T, bool) {
+	var zero T
+	if t, ok := v.(T); ok {
+		return t, true
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.Elem().Type() == reflect.TypeOf(&zero).Elem() {
+		return rv.Elem().Interface().(T), true
+	}
+	return zero, false
+}
+
+// Get resolves the registered implementor of T, the generic counterpart
+// of Container.TryLoad. The second return value reports whether one was
+// found.
+func Get[T any](c Container) (T, bool) {
+	var zero T
+	v, ok := c.TryLoad(new(T))
+	if !ok {
+		return zero, false
+	}
+	return tryAssert[T](v)
+}
+
+// MustGet resolves the registered implementor of T, the generic
+// counterpart of Container.Load. Panics if none exists.
+func MustGet[T any](c Container) T {
+	v := c.Load(new(T))
+	t, ok := tryAssert[T](v)
+	if !ok {
+		panic(fmt.Sprintf("container.MustGet: could not convert %T to %s", v, reflect.TypeOf(&t).Elem()))
+	}
+	return t
+}
+
+// Provide registers fn as a factory for T, the generic counterpart of
+// Container.Provide. Panics immediately if fn isn't a function returning
+// a T (optionally alongside an error).
+func Provide[T any](c Container, fn interface{}) {
+	fnTyp := reflect.TypeOf(fn)
+	if fnTyp == nil || fnTyp.Kind() != reflect.Func || fnTyp.NumOut() == 0 {
+		panic("container.Provide: fn must be a function returning a value")
+	}
+
+	var zero T
+	wantTyp := reflect.TypeOf(&zero).Elem()
+	if !fnTyp.Out(0).AssignableTo(wantTyp) {
+		panic(fmt.Sprintf("container.Provide: fn returns %s, want %s", fnTyp.Out(0), wantTyp))
+	}
+	c.Provide(fn)
+}
+
+// Invoke resolves fn's parameters as dependencies and calls it, the
+// generic counterpart of Container.Invoke, returning its first return
+// value typed as R.
+func Invoke[R any](c Container, fn interface{}) R {
+	out := c.Invoke(fn)
+	if out == nil {
+		var zero R
+		return zero
+	}
+	return out.(R)
+}
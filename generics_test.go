@@ -0,0 +1,90 @@
+package container
+
+import "testing"
+
+type widgetComp struct {
+	Name string
+}
+
+// TestGetConcreteStructType guards against Get/MustGet panicking with a
+// failed type assertion for T a concrete, non-pointer registered struct
+// type: the container only ever stores pointers, so v.(T) fails for such
+// a T even though the component is registered and built.
+func TestGetConcreteStructType(t *testing.T) {
+	c := New()
+	c.Register(&widgetComp{Name: "gizmo"})
+	if _, err := c.Build(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	w, ok := Get[widgetComp](c)
+	if !ok {
+		t.Fatal("expected widgetComp to be found")
+	}
+	if w.Name != "gizmo" {
+		t.Fatalf("got %+v, want Name=gizmo", w)
+	}
+
+	w2 := MustGet[widgetComp](c)
+	if w2.Name != "gizmo" {
+		t.Fatalf("got %+v, want Name=gizmo", w2)
+	}
+}
+
+// TestGetPointerType checks that Get/MustGet still work for T a pointer
+// type, the case that worked before the concrete-type fix.
+func TestGetPointerType(t *testing.T) {
+	c := New()
+	c.Register(&widgetComp{Name: "sprocket"})
+	if _, err := c.Build(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	w, ok := Get[*widgetComp](c)
+	if !ok || w.Name != "sprocket" {
+		t.Fatalf("got %+v, ok=%v, want Name=sprocket", w, ok)
+	}
+}
+
+// TestGenericsInvoke covers container.Invoke resolving fn's parameters
+// as dependencies and returning its first result typed as R.
+func TestGenericsInvoke(t *testing.T) {
+	c := New()
+	c.Register(&widgetComp{Name: "cog"})
+
+	got := Invoke[string](c, func(w *widgetComp) string {
+		return w.Name
+	})
+	if got != "cog" {
+		t.Fatalf("got %q, want %q", got, "cog")
+	}
+}
+
+// TestGenericsProvideRejectsTypeMismatch checks that Provide[T] panics
+// immediately when fn's return type isn't assignable to T, rather than
+// registering a provider that would fail mysteriously later.
+func TestGenericsProvideRejectsTypeMismatch(t *testing.T) {
+	c := New()
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatal("expected Provide[T] to panic for a mismatched return type")
+		}
+	}()
+	Provide[*widgetComp](c, func() string { return "not a widget" })
+}
+
+// TestGenericsProvide checks the success path: Provide[T] registers fn
+// and its output is resolvable the normal way afterward.
+func TestGenericsProvide(t *testing.T) {
+	c := New()
+	Provide[*widgetComp](c, func() *widgetComp { return &widgetComp{Name: "provided"} })
+	if _, err := c.Build(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	w, ok := Get[*widgetComp](c)
+	if !ok || w.Name != "provided" {
+		t.Fatalf("got %+v, ok=%v, want Name=provided", w, ok)
+	}
+}
@@ -0,0 +1,75 @@
+package container
+
+import "testing"
+
+type skipTagConn struct {
+	dsn string
+}
+
+type skipComp struct {
+	Injected *skipTagConn `inject:""`
+	Skipped  *skipTagConn `inject:"-"`
+}
+
+// TestInjectSkipTag checks that an inject:"-" field is left untouched
+// even though a dependency of its type is registered and another field
+// of the same type on the struct does get wired.
+func TestInjectSkipTag(t *testing.T) {
+	c := New()
+	c.Register(&skipTagConn{dsn: "shared"}, skipComp{})
+	if _, err := c.Build(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	s := c.Load(&skipComp{}).(*skipComp)
+	if s.Injected == nil || s.Injected.dsn != "shared" {
+		t.Fatalf("skipComp.Injected should have been wired: %+v", s.Injected)
+	}
+	if s.Skipped != nil {
+		t.Fatalf("skipComp.Skipped should have been left nil, inject:\"-\" should skip it: %+v", s.Skipped)
+	}
+}
+
+type namedTagUser struct {
+	Primary *skipTagConn `inject:"name=primary"`
+}
+
+// TestInjectNameTagOnExportedField checks that an inject:"name=..."
+// struct tag on an exported field resolves to the matching
+// RegisterNamed registration rather than the usual concrete-type lookup.
+func TestInjectNameTagOnExportedField(t *testing.T) {
+	c := New()
+	c.RegisterNamed("primary", &skipTagConn{dsn: "primary-dsn"})
+	c.Register(namedTagUser{})
+	if _, err := c.Build(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	u := c.Load(&namedTagUser{}).(*namedTagUser)
+	if u.Primary == nil || u.Primary.dsn != "primary-dsn" {
+		t.Fatalf("namedTagUser.Primary not wired from name= tag: %+v", u.Primary)
+	}
+}
+
+type unexportedFieldComp struct {
+	conn *skipTagConn `inject:""`
+}
+
+func (u *unexportedFieldComp) DSN() string {
+	return u.conn.dsn
+}
+
+// TestInjectUnexportedField checks that an unexported, tagged field is
+// still wired via the unsafe.Pointer escape hatch in wireComponent.
+func TestInjectUnexportedField(t *testing.T) {
+	c := New()
+	c.Register(&skipTagConn{dsn: "unexported-dsn"}, unexportedFieldComp{})
+	if _, err := c.Build(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	u := c.Load(&unexportedFieldComp{}).(*unexportedFieldComp)
+	if u.DSN() != "unexported-dsn" {
+		t.Fatalf("got DSN()=%q, want unexported-dsn", u.DSN())
+	}
+}
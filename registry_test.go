@@ -0,0 +1,103 @@
+package container
+
+import (
+	"testing"
+)
+
+type Thinger interface {
+	Think() string
+}
+
+type fooThinger struct{}
+
+func (f *fooThinger) Think() string { return "foo" }
+
+type barThinger struct{}
+
+func (b *barThinger) Think() string { return "bar" }
+
+type thinkerUser struct {
+	Thinkers []Thinger `inject:""`
+}
+
+// TestLoadAllFindsPlainRegisteredImplementors guards against LoadAll
+// only checking c.impls, which is only populated by RegisterAsInterface:
+// a type registered with plain Register that happens to implement the
+// interface must still show up, the same way a single-value Thinger
+// field already resolves it via findImplementor's scan.
+func TestLoadAllFindsPlainRegisteredImplementors(t *testing.T) {
+	c := New()
+	c.Register(&fooThinger{}, &barThinger{})
+	if _, err := c.Build(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := c.LoadAll((*Thinger)(nil))
+	if len(got) != 2 {
+		t.Fatalf("got %d implementors, want 2: %v", len(got), got)
+	}
+}
+
+// TestSliceFieldFindsPlainRegisteredImplementors is the field-injection
+// counterpart of TestLoadAllFindsPlainRegisteredImplementors: a []Thinger
+// field must be wired with every plain Register'd implementor, not just
+// ones bound via RegisterAsInterface.
+func TestSliceFieldFindsPlainRegisteredImplementors(t *testing.T) {
+	c := New()
+	c.Register(&fooThinger{}, &barThinger{}, thinkerUser{})
+	if _, err := c.Build(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	u := c.Load(&thinkerUser{}).(*thinkerUser)
+	if len(u.Thinkers) != 2 {
+		t.Fatalf("got %d thinkers, want 2: %v", len(u.Thinkers), u.Thinkers)
+	}
+}
+
+type namedConn struct {
+	dsn string
+}
+
+type namedUser struct {
+	Primary *namedConn `inject:"name=primary"`
+}
+
+// TestRegisterNamedAndLoadNamed covers RegisterNamed/LoadNamed end to
+// end, and the name= tag resolving a field to a specific named
+// registration rather than the usual concrete-type lookup.
+func TestRegisterNamedAndLoadNamed(t *testing.T) {
+	c := New()
+	c.RegisterNamed("primary", &namedConn{dsn: "primary-dsn"})
+	c.Register(namedUser{})
+	if _, err := c.Build(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	loaded := c.LoadNamed("primary", &namedConn{}).(*namedConn)
+	if loaded.dsn != "primary-dsn" {
+		t.Fatalf("got %+v, want dsn=primary-dsn", loaded)
+	}
+
+	u := c.Load(&namedUser{}).(*namedUser)
+	if u.Primary == nil || u.Primary.dsn != "primary-dsn" {
+		t.Fatalf("namedUser.Primary not wired from name= tag: %+v", u.Primary)
+	}
+}
+
+// TestLoadNamedNotFound checks LoadNamed panics with *NamedNotFoundError
+// for a name that was never registered.
+func TestLoadNamedNotFound(t *testing.T) {
+	c := New()
+	if _, err := c.Build(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	defer func() {
+		r := recover()
+		if _, ok := r.(*NamedNotFoundError); !ok {
+			t.Fatalf("got panic %v (%T), want *NamedNotFoundError", r, r)
+		}
+	}()
+	c.LoadNamed("missing", &namedConn{})
+}